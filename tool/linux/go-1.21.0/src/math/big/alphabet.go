@@ -0,0 +1,144 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements Int conversions to and from pluggable digit
+// alphabets, generalizing the fixed 2..62 digit table used by Text and
+// SetString to radixes up to 256.
+
+package big
+
+// Alphabet is an ordered set of distinct digit bytes defining a positional
+// numeral system whose radix equals len(a). It generalizes the fixed
+// 2..62 digit table used internally by Text and SetString to arbitrary
+// digit sets and radixes up to 256, such as the ones used by Base58 and
+// Base64 encodings, letting callers render big integers as short,
+// URL-safe, or collation-friendly identifiers.
+type Alphabet string
+
+// Predefined alphabets for commonly used non-standard bases.
+const (
+	Base58BitcoinAlphabet Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	Base58FlickrAlphabet  Alphabet = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+	Base62Alphabet        Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Base64Alphabet        Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+)
+
+// valid reports whether a can be used as a digit alphabet: its radix
+// (length) must be between 2 and 256 — the range a single byte can index
+// — its bytes must all be distinct, and it must not contain '-', the
+// character TextAlphabet and SetStringAlphabet use to mark a negative
+// value. '+' may appear in a: it is never emitted as a sign by
+// TextAlphabet, and SetStringAlphabet only treats a leading '+' as a
+// sign when '+' is not itself a digit of a.
+func (a Alphabet) valid() bool {
+	if len(a) < 2 || len(a) > 256 {
+		return false
+	}
+	var seen [256]bool
+	for i := 0; i < len(a); i++ {
+		if a[i] == '-' || seen[a[i]] {
+			return false
+		}
+		seen[a[i]] = true
+	}
+	return true
+}
+
+// digitValues returns a lookup table mapping each byte used by a to its
+// digit value; ok[b] is false for bytes not in a.
+func (a Alphabet) digitValues() (values [256]int, ok [256]bool) {
+	for i := 0; i < len(a); i++ {
+		values[a[i]] = i
+		ok[a[i]] = true
+	}
+	return
+}
+
+// TextAlphabet returns the string representation of x using the digit
+// alphabet a, whose radix is len(a) (2..256) rather than the standard
+// library's fixed 2..62 digit table. Since a's radix can exceed MaxBase,
+// conversion does not go through nat's itoa/utoa and instead repeatedly
+// divides by the radix using plain Int arithmetic. A negative x is
+// marked with a leading '-'; this is unambiguous because Alphabet.valid
+// rejects any alphabet containing '-' as a digit. It panics if a is not
+// a valid Alphabet (see Alphabet.valid). If x is a nil pointer it returns
+// "<nil>".
+func (x *Int) TextAlphabet(a Alphabet) string {
+	if !a.valid() {
+		panic("big: invalid Alphabet")
+	}
+	if x == nil {
+		return "<nil>"
+	}
+	if x.Sign() == 0 {
+		return string(a[0])
+	}
+
+	radix := NewInt(int64(len(a)))
+	rem, q, r := new(Int).Abs(x), new(Int), new(Int)
+
+	var values []byte // digit values, least-significant first
+	for rem.Sign() != 0 {
+		q.QuoRem(rem, radix, r)
+		values = append(values, byte(r.Uint64()))
+		rem, q = q, rem
+	}
+
+	out := make([]byte, 0, len(values)+1)
+	if x.neg {
+		out = append(out, '-')
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		out = append(out, a[values[i]])
+	}
+	return string(out)
+}
+
+// SetStringAlphabet sets z to the value of s, interpreted as a signed
+// number in the positional system defined by alphabet a (radix len(a),
+// 2..256), and returns z and a boolean indicating success. Like
+// TextAlphabet, it works entirely through plain Int arithmetic so a's
+// radix is not limited to MaxBase. Unlike SetString, s is a plain
+// optionally-signed sequence of digit bytes from a: no base prefix or
+// digit separators are recognized.
+//
+// '-' (rejected as an alphabet digit by Alphabet.valid) always marks a
+// leading sign. '+' marks a leading sign only when '+' is not itself a
+// digit of a; otherwise a leading '+' is read as that digit, so that an
+// alphabet such as Base64Alphabet, whose digit 62 is '+', round-trips
+// values whose most significant digit is '+' instead of silently
+// mistaking it for a sign.
+func (z *Int) SetStringAlphabet(s string, a Alphabet) (*Int, bool) {
+	if !a.valid() {
+		return nil, false
+	}
+
+	values, ok := a.digitValues()
+
+	neg := false
+	switch {
+	case len(s) > 0 && s[0] == '-':
+		neg = true
+		s = s[1:]
+	case len(s) > 0 && s[0] == '+' && !ok[s[0]]:
+		s = s[1:]
+	}
+	if s == "" {
+		return nil, false
+	}
+
+	radix := NewInt(int64(len(a)))
+	acc := new(Int)
+	for i := 0; i < len(s); i++ {
+		if !ok[s[i]] {
+			return nil, false
+		}
+		acc.Mul(acc, radix)
+		acc.Add(acc, NewInt(int64(values[s[i]])))
+	}
+
+	z.abs = acc.abs
+	z.neg = len(z.abs) > 0 && neg
+	return z, true
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import "testing"
+
+var alphabetRoundTripTests = []struct {
+	alphabet Alphabet
+	vals     []int64
+}{
+	{Base58BitcoinAlphabet, []int64{0, 1, 57, 58, 123456789, -42}},
+	{Base58FlickrAlphabet, []int64{0, 1, 57, 58, 123456789, -42}},
+	{Base62Alphabet, []int64{0, 1, 61, 62, 123456789, -42}},
+	// 62, 62*64+10, and -(62*64+10) each have '+' (digit value 62) as
+	// their leading, most-significant digit in Base64Alphabet.
+	{Base64Alphabet, []int64{0, 1, 63, 64, 123456789, -42, 62, 62*64 + 10, -(62*64 + 10)}},
+}
+
+func TestAlphabetRoundTrip(t *testing.T) {
+	for _, test := range alphabetRoundTripTests {
+		for _, v := range test.vals {
+			x := NewInt(v)
+			s := x.TextAlphabet(test.alphabet)
+			got, ok := new(Int).SetStringAlphabet(s, test.alphabet)
+			if !ok {
+				t.Errorf("SetStringAlphabet(%q, %v) failed to parse TextAlphabet output", s, test.alphabet)
+				continue
+			}
+			if got.Cmp(x) != 0 {
+				t.Errorf("%v.TextAlphabet(%v) = %q; SetStringAlphabet round-trip got %s, want %d", v, test.alphabet, s, got, v)
+			}
+		}
+	}
+}
+
+func TestAlphabetZero(t *testing.T) {
+	got := NewInt(0).TextAlphabet(Base62Alphabet)
+	if want := string(Base62Alphabet[0]); got != want {
+		t.Errorf("0.TextAlphabet(Base62Alphabet) = %q; want %q", got, want)
+	}
+}
+
+func TestAlphabetNil(t *testing.T) {
+	var x *Int
+	if got := x.TextAlphabet(Base62Alphabet); got != "<nil>" {
+		t.Errorf("nil.TextAlphabet(...) = %q; want \"<nil>\"", got)
+	}
+}
+
+var invalidAlphabets = []Alphabet{
+	"",                          // too short
+	"a",                         // radix 1
+	"aa",                        // duplicate byte
+	Alphabet(make([]byte, 257)), // too long, and all zero bytes (also duplicates)
+	"ab-cd",                     // contains '-', the sign marker
+}
+
+func TestAlphabetInvalid(t *testing.T) {
+	for i, a := range invalidAlphabets {
+		if a.valid() {
+			t.Errorf("#%d: %v.valid() = true; want false", i, []byte(a))
+		}
+	}
+}
+
+func TestAlphabetInvalidPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("TextAlphabet with invalid Alphabet did not panic")
+		}
+	}()
+	NewInt(1).TextAlphabet(Alphabet("aa"))
+}
+
+func TestSetStringAlphabetInvalid(t *testing.T) {
+	if _, ok := new(Int).SetStringAlphabet("1", Alphabet("aa")); ok {
+		t.Error("SetStringAlphabet with invalid Alphabet returned ok = true")
+	}
+}
+
+func TestSetStringAlphabetRejectsUnknownDigits(t *testing.T) {
+	if _, ok := new(Int).SetStringAlphabet("1_0", Base62Alphabet); ok {
+		t.Error(`SetStringAlphabet("1_0", Base62Alphabet) = ok; want failure (no digit separators)`)
+	}
+	if _, ok := new(Int).SetStringAlphabet("", Base62Alphabet); ok {
+		t.Error(`SetStringAlphabet("", Base62Alphabet) = ok; want failure (empty mantissa)`)
+	}
+}
+
+// TestAlphabetPlusDigitNotMistakenForSign guards against a regression
+// where a leading '+' was always stripped as a sign, even when '+' is
+// itself a digit of the alphabet (as in Base64Alphabet, where '+' is
+// digit value 62): NewInt(62*64+10).TextAlphabet(Base64Alphabet) used to
+// round-trip through SetStringAlphabet as 10 instead of 3978.
+func TestAlphabetPlusDigitNotMistakenForSign(t *testing.T) {
+	x := NewInt(62*64 + 10)
+	s := x.TextAlphabet(Base64Alphabet)
+	if s != "+K" {
+		t.Fatalf("TextAlphabet = %q; want %q", s, "+K")
+	}
+	got, ok := new(Int).SetStringAlphabet(s, Base64Alphabet)
+	if !ok {
+		t.Fatalf("SetStringAlphabet(%q, Base64Alphabet) failed", s)
+	}
+	if got.Cmp(x) != 0 {
+		t.Errorf("SetStringAlphabet(%q, Base64Alphabet) = %s; want %d", s, got, x.Int64())
+	}
+}
+
+// A radix-64 alphabet, previously unreachable because TextAlphabet and
+// SetStringAlphabet routed through nat's itoa/scan, which panic above
+// MaxBase (62).
+func TestBase64AlphabetRadix(t *testing.T) {
+	if len(Base64Alphabet) != 64 {
+		t.Fatalf("len(Base64Alphabet) = %d; want 64", len(Base64Alphabet))
+	}
+	x := NewInt(64*64 + 5)
+	if s := x.TextAlphabet(Base64Alphabet); len(s) != 3 {
+		t.Errorf("TextAlphabet at radix 64 produced %q, wanted 3 digits for value %v", s, x)
+	}
+}
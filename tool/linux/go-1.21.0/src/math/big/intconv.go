@@ -10,6 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/bits"
+	"strconv"
+	"unicode/utf8"
 )
 
 // Text returns the string representation of x in the given base.
@@ -40,6 +43,200 @@ func (x *Int) String() string {
 	return x.Text(10)
 }
 
+// AppendText implements the encoding.TextAppender-style convention: it
+// appends the decimal representation of x, as generated by x.Text(10),
+// to b and returns the extended buffer. It always returns a nil error.
+func (x *Int) AppendText(b []byte) ([]byte, error) {
+	return x.Append(b, 10), nil
+}
+
+// writeToChunkDigits returns the number of base digits per uint64 chunk:
+// the largest n such that base^(n+1) <= MaxUint64 (e.g. 18 for base 10),
+// one digit fewer than the most a uint64 can hold.
+func writeToChunkDigits(base int) int {
+	n := 0
+	limit := uint64(1<<64 - 1)
+	for v := uint64(1); ; n++ {
+		nv := v * uint64(base)
+		if nv/uint64(base) != v || nv > limit/uint64(base) {
+			break
+		}
+		v = nv
+	}
+	if n == 0 {
+		n = 1
+	}
+	return n
+}
+
+// appendPaddedUint appends the base-b representation of u to buf, left
+// padding it with '0' to exactly width digits.
+func appendPaddedUint(buf []byte, u uint64, base, width int) []byte {
+	start := len(buf)
+	buf = strconv.AppendUint(buf, u, base)
+	if pad := width - (len(buf) - start); pad > 0 {
+		buf = append(buf, make([]byte, pad)...)
+		copy(buf[start+pad:], buf[start:len(buf)-pad])
+		for i := 0; i < pad; i++ {
+			buf[start+i] = '0'
+		}
+	}
+	return buf
+}
+
+// WriteTo writes the base-N text representation of x to w, without ever
+// building the complete digit string as one contiguous allocation the
+// way Text or Append do. For power-of-two bases it performs a windowed
+// scan over x, extracting one machine-word-sized group of bits at a
+// time. For other bases it uses the same recursive divide-and-conquer
+// split used internally for big-to-string conversion: it recursively
+// divides x at a chunkBase^k midpoint, emits the high half, then the
+// zero-padded low half, bottoming out at machine-word-sized leaf chunks
+// that are rendered with strconv.AppendUint and flushed directly to w.
+// This keeps the largest single allocation proportional to one division
+// step's operands rather than the full result, and turns what a naive
+// repeated single-word-chunk division would make an O(digits²)-time
+// conversion into an O(digits·log(digits))-ish one — the same complexity
+// class nat's internal conversion has — which together make streaming
+// million-digit integers into a bufio.Writer, a hash, or a network
+// connection considerably cheaper than materializing the whole result
+// first.
+//
+// Base must be between 2 and 36, inclusive — the range strconv.AppendUint
+// supports; use Text or Append for the fuller 2..62 alphabet. WriteTo
+// returns the number of bytes written and the first error encountered.
+func (x *Int) WriteTo(w io.Writer, base int) (n int64, err error) {
+	if base < 2 || base > 36 {
+		panic("big: invalid WriteTo base " + strconv.Itoa(base))
+	}
+	if x == nil {
+		nn, err := io.WriteString(w, "<nil>")
+		return int64(nn), err
+	}
+
+	if x.neg {
+		nn, werr := io.WriteString(w, "-")
+		n += int64(nn)
+		if werr != nil {
+			return n, werr
+		}
+	}
+
+	abs := new(Int).Abs(x)
+	if abs.Sign() == 0 {
+		nn, werr := io.WriteString(w, "0")
+		return n + int64(nn), werr
+	}
+
+	if base&(base-1) == 0 {
+		nn, werr := writeToPow2(w, abs, base)
+		return n + nn, werr
+	}
+	nn, werr := writeToGeneric(w, abs, base)
+	return n + nn, werr
+}
+
+// writeToGeneric streams the digits of the positive value abs in the
+// given (non-power-of-two) base, most-significant chunk first, via
+// recursive divide-and-conquer splitting.
+func writeToGeneric(w io.Writer, abs *Int, base int) (n int64, err error) {
+	chunkDigits := writeToChunkDigits(base)
+	chunkBase := new(Int).Exp(NewInt(int64(base)), NewInt(int64(chunkDigits)), nil)
+	groups := writeToGroupCount(abs, chunkBase)
+	return writeToSplit(w, abs, chunkBase, base, chunkDigits, groups, true)
+}
+
+// writeToGroupCount returns the smallest k such that abs < chunkBase^k,
+// i.e. the number of chunkBase-sized digit groups needed to represent
+// abs. It finds k with a handful of chunkBase exponentiations rather
+// than materializing abs's digits, by doubling to bracket k and then
+// binary searching the bracket.
+func writeToGroupCount(abs, chunkBase *Int) int {
+	k, pow := 1, new(Int).Set(chunkBase)
+	for pow.Cmp(abs) <= 0 {
+		k *= 2
+		pow.Mul(pow, pow)
+	}
+	lo, hi := k/2, k
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		if new(Int).Exp(chunkBase, NewInt(int64(mid)), nil).Cmp(abs) <= 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// writeToSplit streams the non-negative value v — known to be smaller
+// than chunkBase^groups — to w, most-significant chunk first. While more
+// than one chunk group remains it recursively splits v at the
+// chunkBase^(groups/2) midpoint into a high and low part and streams
+// each in turn; once a single group remains it renders v directly with
+// strconv.AppendUint. topLevel suppresses zero-padding for the very
+// first chunk written overall.
+func writeToSplit(w io.Writer, v, chunkBase *Int, base, chunkDigits, groups int, topLevel bool) (n int64, err error) {
+	if groups <= 1 {
+		buf := make([]byte, 0, chunkDigits)
+		if topLevel {
+			buf = strconv.AppendUint(buf, v.Uint64(), base)
+		} else {
+			buf = appendPaddedUint(buf, v.Uint64(), base, chunkDigits)
+		}
+		nn, werr := w.Write(buf)
+		return int64(nn), werr
+	}
+
+	half := groups / 2
+	split := new(Int).Exp(chunkBase, NewInt(int64(half)), nil)
+	hi, lo := new(Int), new(Int)
+	hi.QuoRem(v, split, lo)
+
+	nn, err := writeToSplit(w, hi, chunkBase, base, chunkDigits, groups-half, topLevel)
+	n += nn
+	if err != nil {
+		return n, err
+	}
+	nn, err = writeToSplit(w, lo, chunkBase, base, chunkDigits, half, false)
+	n += nn
+	return n, err
+}
+
+// writeToPow2 streams the digits of the positive value abs in the given
+// power-of-two base by extracting one machine-word-sized window of bits
+// at a time, most-significant window first.
+func writeToPow2(w io.Writer, abs *Int, base int) (n int64, err error) {
+	bitsPerDigit := bits.TrailingZeros(uint(base))
+	chunkDigits := 64 / bitsPerDigit
+	chunkBits := uint(chunkDigits * bitsPerDigit)
+	mask := new(Int).Lsh(NewInt(1), chunkBits)
+	mask.Sub(mask, NewInt(1))
+
+	numChunks := (abs.BitLen() + int(chunkBits) - 1) / int(chunkBits)
+
+	var buf []byte
+	tmp := new(Int)
+	for i := numChunks - 1; i >= 0; i-- {
+		tmp.Rsh(abs, uint(i)*chunkBits)
+		tmp.And(tmp, mask)
+		u := tmp.Uint64()
+
+		buf = buf[:0]
+		if i == numChunks-1 {
+			buf = strconv.AppendUint(buf, u, base)
+		} else {
+			buf = appendPaddedUint(buf, u, base, chunkDigits)
+		}
+		nn, werr := w.Write(buf)
+		n += int64(nn)
+		if werr != nil {
+			return n, werr
+		}
+	}
+	return n, nil
+}
+
 // write count copies of text to s.
 func writeMultiple(s fmt.State, text string, count int) {
 	if len(text) > 0 {
@@ -54,15 +251,28 @@ var _ fmt.Formatter = intOne // *Int must implement fmt.Formatter
 
 // Format implements fmt.Formatter. It accepts the formats
 // 'b' (binary), 'o' (octal with 0 prefix), 'O' (octal with 0o prefix),
-// 'd' (decimal), 'x' (lowercase hexadecimal), and
-// 'X' (uppercase hexadecimal).
+// 'd' (decimal), 'x' (lowercase hexadecimal),
+// 'X' (uppercase hexadecimal), 'U' (Unicode code point, e.g. "U+1F600"),
+// ',' (decimal, digits grouped with ',' every 3 digits, e.g. "1,234,567"),
+// and '_' (decimal, digits grouped with '_' every 3 digits, e.g.
+// "1_234_567"). ',' and '_' are verbs rather than flags: package fmt
+// recognizes only '#', '0', '+', '-', and ' ' as flags, so a character
+// such as ',' can never reach Format through s.Flag and is written as
+// the verb itself, e.g. fmt.Sprintf("%,", x), not "%,d". The usual
+// width, precision, sign, and zero-padding apply to ',' and '_' exactly
+// as they do to 'd'.
+// 'U' uses a minimum precision of 4 and rejects negative values, since
+// code points are non-negative; with the '#' flag it also appends the
+// quoted character, e.g. "U+0041 'A'", when x fits in a valid rune.
 // Also supported are the full suite of package fmt's format
 // flags for integral types, including '+' and ' ' for sign
 // control, '#' for leading zero in octal and for hexadecimal,
 // a leading "0x" or "0X" for "%#x" and "%#X" respectively,
 // specification of minimum digits precision, output field
 // width, space or zero padding, and '-' for left or right
-// justification.
+// justification. If a default Printer has been installed with
+// SetDefaultPrinter, its grouping rule and separator are used for
+// the 'd', 'b', 'o', 'O', 'x', and 'X' verbs.
 func (x *Int) Format(s fmt.State, ch rune) {
 	// determine base
 	var base int
@@ -71,10 +281,12 @@ func (x *Int) Format(s fmt.State, ch rune) {
 		base = 2
 	case 'o', 'O':
 		base = 8
-	case 'd', 's', 'v':
+	case 'd', 's', 'v', ',', '_':
 		base = 10
 	case 'x', 'X':
 		base = 16
+	case 'U':
+		base = 16
 	default:
 		// unknown format
 		fmt.Fprintf(s, "%%!%c(big.Int=%s)", ch, x.String())
@@ -86,9 +298,17 @@ func (x *Int) Format(s fmt.State, ch rune) {
 		return
 	}
 
+	if ch == 'U' && x.neg {
+		// Unicode code points are non-negative
+		fmt.Fprintf(s, "%%!%c(big.Int=%s)", ch, x.String())
+		return
+	}
+
 	// determine sign character
 	sign := ""
 	switch {
+	case ch == 'U':
+		// no sign for Unicode code points
 	case x.neg:
 		sign = "-"
 	case s.Flag('+'): // supersedes ' ' when both specified
@@ -114,58 +334,205 @@ func (x *Int) Format(s fmt.State, ch rune) {
 	if ch == 'O' {
 		prefix = "0o"
 	}
+	if ch == 'U' {
+		prefix = "U+"
+	}
 
-	digits := x.abs.utoa(base)
-	if ch == 'X' {
+	rawDigits := x.abs.utoa(base)
+	if ch == 'X' || ch == 'U' {
 		// faster than bytes.ToUpper
-		for i, d := range digits {
+		for i, d := range rawDigits {
 			if 'a' <= d && d <= 'z' {
-				digits[i] = 'A' + (d - 'a')
+				rawDigits[i] = 'A' + (d - 'a')
 			}
 		}
 	}
 
-	// number of characters for the three classes of number padding
+	// number of characters for the two remaining classes of padding
+	// (grouping- and precision-driven zero digits are resolved below,
+	// directly against rawDigits, before any grouping separator is
+	// inserted, so the digit count they see is never inflated by
+	// separator bytes)
 	var left int  // space characters to left of digits for right justification ("%8d")
-	var zeros int // zero characters (actually cs[0]) as left-most digits ("%.8d")
 	var right int // space characters to right of digits for left justification ("%-8d")
 
 	// determine number padding from precision: the least number of digits to output
 	precision, precisionSet := s.Precision()
-	if precisionSet {
+	if ch == 'U' && !precisionSet {
+		// 'U' defaults to a minimum of 4 hex digits, e.g. "U+0041"
+		precision, precisionSet = 4, true
+	}
+	if precisionSet && len(rawDigits) == 1 && rawDigits[0] == '0' && precision == 0 {
+		return // print nothing if zero value (x == 0) and zero precision ("." or ".0")
+	}
+	precZeros := 0
+	if precisionSet && len(rawDigits) < precision {
+		precZeros = precision - len(rawDigits)
+	}
+
+	// for "%#U" also quote the corresponding rune, if it is one, e.g. "U+0041 'A'"
+	quoted := ""
+	if ch == 'U' && s.Flag('#') && x.IsUint64() {
+		if v := x.Uint64(); v <= utf8.MaxRune {
+			if r := rune(v); utf8.ValidRune(r) {
+				quoted = " " + strconv.QuoteRune(r)
+			}
+		}
+	}
+
+	// group determines the separator (if any) that should be applied to
+	// the zero-padded digit sequence: the explicit ',' or '_' verb takes
+	// precedence over a default Printer installed via SetDefaultPrinter.
+	groupSize, groupSep := 0, byte(0)
+	var printer *Printer
+	switch {
+	case ch == ',' || ch == '_':
+		groupSize, groupSep = 3, byte(ch)
+	case ch != 's' && ch != 'v' && ch != 'U':
+		printer = currentDefaultPrinter()
+	}
+
+	// group renders extraZeros leading zero digits followed by
+	// rawDigits, then applies the separator determined above. Computing
+	// zero padding before grouping (rather than grouping rawDigits and
+	// padding the grouped result) ensures separator bytes are never
+	// mistaken for significant digits.
+	group := func(extraZeros int) []byte {
+		padded := rawDigits
+		if extraZeros > 0 {
+			padded = make([]byte, extraZeros+len(rawDigits))
+			for i := 0; i < extraZeros; i++ {
+				padded[i] = '0'
+			}
+			copy(padded[extraZeros:], rawDigits)
+		}
 		switch {
-		case len(digits) < precision:
-			zeros = precision - len(digits) // count of zero padding
-		case len(digits) == 1 && digits[0] == '0' && precision == 0:
-			return // print nothing if zero value (x == 0) and zero precision ("." or ".0")
+		case groupSize > 0:
+			return groupDigits(padded, groupSize, groupSep)
+		case printer != nil:
+			return printer.groupDigits(padded)
+		default:
+			return padded
 		}
 	}
 
+	digits := group(precZeros)
+
 	// determine field pad from width: the least number of characters to output
-	length := len(sign) + len(prefix) + zeros + len(digits)
+	length := len(sign) + len(prefix) + len(digits) + len(quoted)
 	if width, widthSet := s.Width(); widthSet && length < width { // pad as specified
 		switch d := width - length; {
 		case s.Flag('-'):
 			// pad on the right with spaces; supersedes '0' when both specified
 			right = d
 		case s.Flag('0') && !precisionSet:
-			// pad with zeros unless precision also specified
-			zeros = d
+			// pad with zeros unless precision also specified; re-group
+			// after each attempt since added zeros may add separators
+			for extra := precZeros + d; ; extra++ {
+				candidate := group(extra)
+				if got := len(sign) + len(prefix) + len(candidate) + len(quoted); got >= width {
+					digits, length = candidate, got
+					break
+				}
+			}
 		default:
 			// pad on the left with spaces
 			left = d
 		}
 	}
 
-	// print number as [left pad][sign][prefix][zero pad][digits][right pad]
+	// print number as [left pad][sign][prefix][digits][quoted rune][right pad]
 	writeMultiple(s, " ", left)
 	writeMultiple(s, sign, 1)
 	writeMultiple(s, prefix, 1)
-	writeMultiple(s, "0", zeros)
 	s.Write(digits)
+	writeMultiple(s, quoted, 1)
 	writeMultiple(s, " ", right)
 }
 
+// groupDigits inserts sep into digits every group digits, counting from the
+// least-significant (rightmost) end, and returns the result. It never
+// inserts a separator before the first digit. digits is not modified.
+func groupDigits(digits []byte, group int, sep byte) []byte {
+	n := len(digits)
+	if group <= 0 || n <= group {
+		return digits
+	}
+	seps := (n - 1) / group
+	out := make([]byte, n+seps)
+	si := len(out)
+	count := 0
+	for i := n - 1; i >= 0; i-- {
+		si--
+		out[si] = digits[i]
+		count++
+		if count%group == 0 && i != 0 {
+			si--
+			out[si] = sep
+		}
+	}
+	return out
+}
+
+// isDigitSepByte reports whether c can appear adjacent to an underscore
+// digit separator, i.e. it is one of the alphanumeric mantissa digit bytes
+// accepted by scan for some base.
+func isDigitSepByte(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// stripDigitSeparators removes Go 1.13-style underscore digit separators
+// from the mantissa of s, leaving an optional leading sign untouched. A
+// separator is legal only directly between two mantissa digits: it may not
+// lead or trail the mantissa, nor appear doubled. It reports the cleaned
+// string and whether s was well-formed.
+func stripDigitSeparators(s string) (cleaned string, ok bool) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	mantissa := s[i:]
+	if mantissa == "" {
+		return "", false
+	}
+	buf := make([]byte, 0, len(mantissa))
+	prevDigit := false
+	pendingSep := false
+	for j := 0; j < len(mantissa); j++ {
+		c := mantissa[j]
+		if c == '_' {
+			if !prevDigit || pendingSep {
+				return "", false
+			}
+			pendingSep = true
+			continue
+		}
+		buf = append(buf, c)
+		prevDigit = isDigitSepByte(c)
+		pendingSep = false
+	}
+	if pendingSep || !prevDigit {
+		return "", false
+	}
+	return s[:i] + string(buf), true
+}
+
+// SetStringWithSep is like SetString but additionally accepts Go
+// 1.13-style underscore digit separators between mantissa digits even
+// when base is a nonzero, explicit value; SetString (and this method with
+// base == 0) already accept them via the usual literal-prefix detection.
+// A misplaced, leading, trailing, or doubled separator is a parse error.
+func (z *Int) SetStringWithSep(s string, base int) (*Int, bool) {
+	if base == 0 {
+		return z.SetString(s, base)
+	}
+	cleaned, ok := stripDigitSeparators(s)
+	if !ok {
+		return nil, false
+	}
+	return z.SetString(cleaned, base)
+}
+
 // scan sets z to the integer value corresponding to the longest possible prefix
 // read from r representing a signed integer number in a given conversion base.
 // It returns z, the actual conversion base used, and an error, if any. In the
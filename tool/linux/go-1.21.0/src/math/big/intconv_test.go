@@ -0,0 +1,197 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+var groupFormatTests = []struct {
+	input  string
+	format string
+	output string
+}{
+	{"1234567", "%,", "1,234,567"},
+	{"1234567", "%_", "1_234_567"},
+	{"-1234567", "%,", "-1,234,567"},
+	{"123", "%,", "123"},
+	{"0", "%,", "0"},
+	{"1234567", "%+,", "+1,234,567"},
+	{"1234567", "% ,", " 1,234,567"},
+	{"1234567", "%.10,", "0,001,234,567"},
+	{"1234567", "%010,", "01,234,567"},
+	{"7", "%9,", "        7"},
+	{"1234567", "%-12,", "1,234,567   "},
+}
+
+func TestIntFormatGrouping(t *testing.T) {
+	for i, test := range groupFormatTests {
+		x, ok := new(Int).SetString(test.input, 0)
+		if !ok {
+			t.Errorf("#%d failed reading input %s", i, test.input)
+			continue
+		}
+		output := fmt.Sprintf(test.format, x)
+		if output != test.output {
+			t.Errorf("#%d got %q; want %q, {%q, %q, %q}", i, output, test.output, test.input, test.format, test.output)
+		}
+	}
+}
+
+var setStringWithSepTests = []struct {
+	in   string
+	base int
+	val  int64
+	ok   bool
+}{
+	{"1_234_567", 10, 1234567, true},
+	{"1234567", 10, 1234567, true},
+	{"dead_beef", 16, 0xdeadbeef, true},
+	{"-1_000", 10, -1000, true},
+	{"+1_000", 10, 1000, true},
+	{"1__234", 10, 0, false}, // doubled separator
+	{"_1234", 10, 0, false},  // leading separator
+	{"1234_", 10, 0, false},  // trailing separator
+	{"-_1234", 10, 0, false}, // separator right after sign
+	{"", 10, 0, false},       // empty mantissa
+	{"12x4", 10, 0, false},   // base argument is still honored
+	{"1_234_567", 0, 1234567, true},
+}
+
+func TestSetStringWithSep(t *testing.T) {
+	for i, test := range setStringWithSepTests {
+		n, ok := new(Int).SetStringWithSep(test.in, test.base)
+		if ok != test.ok {
+			t.Errorf("#%d (input %q, base %d) ok = %v; want %v", i, test.in, test.base, ok, test.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if want := NewInt(test.val); n.Cmp(want) != 0 {
+			t.Errorf("#%d (input %q, base %d) got %s; want %d", i, test.in, test.base, n, test.val)
+		}
+	}
+}
+
+var writeToTests = []struct {
+	input string
+	base  int
+}{
+	{"0", 2},
+	{"0", 10},
+	{"0", 16},
+	{"10", 10},
+	{"-10", 10},
+	{"1234567890123456789012345678901234567890", 10},
+	{"-1234567890123456789012345678901234567890", 16},
+	{"1234567890123456789012345678901234567890", 2},
+	{"1234567890123456789012345678901234567890", 32},
+	{"1234567890123456789012345678901234567890", 36},
+	{"255", 16},
+	{"255", 2},
+}
+
+func TestIntWriteTo(t *testing.T) {
+	for i, test := range writeToTests {
+		x, ok := new(Int).SetString(test.input, 0)
+		if !ok {
+			t.Errorf("#%d failed reading input %s", i, test.input)
+			continue
+		}
+		want := x.Text(test.base)
+
+		var buf bytes.Buffer
+		n, err := x.WriteTo(&buf, test.base)
+		if err != nil {
+			t.Errorf("#%d (base %d) unexpected error: %v", i, test.base, err)
+			continue
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("#%d (base %d) got %q; want %q", i, test.base, got, want)
+		}
+		if n != int64(len(want)) {
+			t.Errorf("#%d (base %d) got n=%d; want %d", i, test.base, n, len(want))
+		}
+	}
+}
+
+func TestIntWriteToInvalidBase(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("WriteTo(base=37) did not panic")
+		}
+	}()
+	new(Int).WriteTo(&bytes.Buffer{}, 37)
+}
+
+func TestIntWriteToNil(t *testing.T) {
+	var x *Int
+	var buf bytes.Buffer
+	n, err := x.WriteTo(&buf, 10)
+	if err != nil || buf.String() != "<nil>" || n != int64(len("<nil>")) {
+		t.Errorf("got %q, n=%d, err=%v; want %q, n=%d, err=nil", buf.String(), n, err, "<nil>", len("<nil>"))
+	}
+}
+
+var appendTextTests = []string{
+	"0", "10", "-10", "1234567890123456789012345678901234567890",
+}
+
+func TestIntAppendText(t *testing.T) {
+	for i, in := range appendTextTests {
+		x, ok := new(Int).SetString(in, 0)
+		if !ok {
+			t.Errorf("#%d failed reading input %s", i, in)
+			continue
+		}
+		buf, err := x.AppendText([]byte("prefix:"))
+		if err != nil {
+			t.Errorf("#%d unexpected error: %v", i, err)
+			continue
+		}
+		if want := "prefix:" + x.Text(10); string(buf) != want {
+			t.Errorf("#%d got %q; want %q", i, buf, want)
+		}
+	}
+}
+
+var unicodeFormatTests = []struct {
+	input  string
+	format string
+	output string
+}{
+	{"65", "%U", "U+0041"},
+	{"65", "%#U", "U+0041 'A'"},
+	{"128512", "%U", "U+1F600"},
+	{"128512", "%#U", "U+1F600 '😀'"},
+	{"0", "%U", "U+0000"},
+	{"-1", "%U", "%!U(big.Int=-1)"},
+	{"<nil>", "%U", "<nil>"},
+	{"1114112", "%#U", "U+110000"},      // utf8.MaxRune+1: no quoted rune
+	{"4294967296", "%U", "U+100000000"}, // beyond rune range entirely
+	{"10", "%8U", "  U+000A"},
+	{"10", "%.2U", "U+0A"},
+}
+
+func TestIntFormatUnicode(t *testing.T) {
+	for i, test := range unicodeFormatTests {
+		var x *Int
+		if test.input != "<nil>" {
+			var ok bool
+			x, ok = new(Int).SetString(test.input, 0)
+			if !ok {
+				t.Errorf("#%d failed reading input %s", i, test.input)
+				continue
+			}
+		}
+		output := fmt.Sprintf(test.format, x)
+		if output != test.output {
+			t.Errorf("#%d got %q; want %q, {%q, %q, %q}", i, output, test.output, test.input, test.format, test.output)
+		}
+	}
+}
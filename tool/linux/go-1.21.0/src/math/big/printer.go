@@ -0,0 +1,116 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements locale-aware, human-readable formatting of Int
+// values via a Printer, as a complement to the machine-readable Text,
+// Append, and Format.
+
+package big
+
+import "sync"
+
+// Printer formats Int values for human-readable, locale-aware display,
+// e.g. "1,234,567" or "1.234.567". It is a presentation helper layered on
+// top of Text; unlike Text or Format, its output is not intended to be
+// parsed back with SetString.
+type Printer struct {
+	// GroupSize is the number of digits between consecutive group
+	// separators, counting from the least-significant digit. A
+	// GroupSize of 0 disables grouping. A negative GroupSize selects
+	// Indian digit grouping: groups of 2 digits, except the
+	// least-significant group, which has 3 (e.g. "12,34,567").
+	GroupSize int
+
+	// GroupSep separates digit groups, e.g. ",". Grouping is disabled
+	// if GroupSep is empty.
+	GroupSep string
+
+	// DecimalSep separates the integer and fractional parts of a
+	// formatted value, e.g. ".". It is unused by (*Printer).Format on
+	// an Int, which has no fractional part.
+	DecimalSep string
+}
+
+// groupSizeAt returns the size of the k-th digit group counting from the
+// least-significant group (k == 0).
+func (p *Printer) groupSizeAt(k int) int {
+	if p.GroupSize > 0 {
+		return p.GroupSize
+	}
+	// Indian grouping: 3, then repeating groups of 2.
+	if k == 0 {
+		return 3
+	}
+	return 2
+}
+
+// groupDigits inserts p.GroupSep between consecutive digit groups of
+// digits (which must not include a sign) and returns the result. digits
+// is not modified.
+func (p *Printer) groupDigits(digits []byte) []byte {
+	if p.GroupSize == 0 || p.GroupSep == "" || len(digits) == 0 {
+		return digits
+	}
+
+	var groups [][]byte
+	for n, k := len(digits), 0; n > 0; k++ {
+		size := p.groupSizeAt(k)
+		if size <= 0 || size > n {
+			size = n
+		}
+		groups = append(groups, digits[n-size:n])
+		n -= size
+	}
+
+	out := make([]byte, 0, len(digits)+len(groups)*len(p.GroupSep))
+	for i := len(groups) - 1; i >= 0; i-- {
+		out = append(out, groups[i]...)
+		if i != 0 {
+			out = append(out, p.GroupSep...)
+		}
+	}
+	return out
+}
+
+// Format returns the grouped base representation of x, using p's grouping
+// rule and separator. Base must be between 2 and 62, inclusive, as for
+// Text. If x is a nil pointer it returns "<nil>".
+func (p *Printer) Format(x *Int, base int) string {
+	if x == nil {
+		return "<nil>"
+	}
+	sign := ""
+	if x.neg {
+		sign = "-"
+	}
+	digits := p.groupDigits(x.abs.utoa(base))
+	return sign + string(digits)
+}
+
+var (
+	defaultPrinterMu sync.RWMutex
+	defaultPrinter   *Printer
+)
+
+// SetDefaultPrinter installs p as the default Printer consulted by
+// (*Int).Format for the 'd', 'b', 'o', 'O', 'x', and 'X' verbs; it has no
+// effect on the 's', 'v', ',', and '_' verbs, the last two of which carry
+// their own fixed grouping. Passing nil restores the unformatted
+// default (no grouping). SetDefaultPrinter is typically called once at
+// program startup to apply a process-wide locale preference; the
+// installation itself is safe for concurrent use, but p's fields must
+// not be mutated afterwards while other goroutines may be formatting.
+func SetDefaultPrinter(p *Printer) {
+	defaultPrinterMu.Lock()
+	defer defaultPrinterMu.Unlock()
+	defaultPrinter = p
+}
+
+// currentDefaultPrinter returns the Printer installed by SetDefaultPrinter,
+// or nil if none has been installed.
+func currentDefaultPrinter() *Printer {
+	defaultPrinterMu.RLock()
+	defer defaultPrinterMu.RUnlock()
+	return defaultPrinter
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"fmt"
+	"testing"
+)
+
+var printerFormatTests = []struct {
+	p     Printer
+	input int64
+	base  int
+	want  string
+}{
+	{Printer{GroupSize: 3, GroupSep: ","}, 1234567, 10, "1,234,567"},
+	{Printer{GroupSize: 3, GroupSep: "."}, 1234567, 10, "1.234.567"},
+	{Printer{GroupSize: -1, GroupSep: ","}, 1234567, 10, "12,34,567"}, // Indian grouping
+	{Printer{GroupSize: 3, GroupSep: ","}, 123, 10, "123"},
+	{Printer{GroupSize: 3, GroupSep: ","}, 0, 10, "0"},
+	{Printer{GroupSize: 3, GroupSep: ","}, -1234567, 10, "-1,234,567"},
+	{Printer{}, 1234567, 10, "1234567"}, // no GroupSep: grouping disabled
+	{Printer{GroupSize: 4, GroupSep: " "}, 0xdeadbeef, 16, "dead beef"},
+}
+
+func TestPrinterFormat(t *testing.T) {
+	for i, test := range printerFormatTests {
+		got := test.p.Format(NewInt(test.input), test.base)
+		if got != test.want {
+			t.Errorf("#%d got %q; want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestPrinterFormatNil(t *testing.T) {
+	p := Printer{GroupSize: 3, GroupSep: ","}
+	var x *Int
+	if got := p.Format(x, 10); got != "<nil>" {
+		t.Errorf("Format(nil) = %q; want \"<nil>\"", got)
+	}
+}
+
+func TestDefaultPrinter(t *testing.T) {
+	SetDefaultPrinter(&Printer{GroupSize: 3, GroupSep: "."})
+	defer SetDefaultPrinter(nil)
+
+	x := NewInt(1234567)
+	if got, want := fmt.Sprintf("%d", x), "1.234.567"; got != want {
+		t.Errorf("%%d with default Printer = %q; want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%v", x), "1234567"; got != want {
+		t.Errorf("%%v with default Printer = %q; want %q (v must not be grouped)", got, want)
+	}
+	if got, want := fmt.Sprintf("%.10d", x), "0.001.234.567"; got != want {
+		t.Errorf("%%.10d with default Printer = %q; want %q", got, want)
+	}
+}
+
+func TestDefaultPrinterNil(t *testing.T) {
+	if got, want := fmt.Sprintf("%d", NewInt(1234567)), "1234567"; got != want {
+		t.Errorf("with no default Printer installed, %%d = %q; want %q", got, want)
+	}
+}
+
+// FuzzPrinterGroupSizes compares the width of a Printer-grouped decimal
+// string against an independently computed golden width: the number of
+// digits plus one separator for every full group boundary strictly
+// inside the digit sequence, counting from the least-significant digit.
+func FuzzPrinterGroupSizes(f *testing.F) {
+	f.Add(uint64(1234567), 3)
+	f.Add(uint64(0), 3)
+	f.Add(uint64(100), 1)
+	f.Add(uint64(999999999999), 4)
+
+	f.Fuzz(func(t *testing.T, v uint64, groupSize int) {
+		if groupSize <= 0 || groupSize > 32 {
+			return
+		}
+		x := new(Int).SetUint64(v)
+		digits := x.Text(10)
+
+		p := Printer{GroupSize: groupSize, GroupSep: ","}
+		got := p.Format(x, 10)
+
+		wantSeps := (len(digits) - 1) / groupSize
+		if want := len(digits) + wantSeps; len(got) != want {
+			t.Errorf("Format(%d) with GroupSize=%d produced %q (len %d); want golden length %d", v, groupSize, got, len(got), want)
+		}
+
+		// Stripping every GroupSep byte must recover the original digits.
+		stripped := make([]byte, 0, len(digits))
+		for i := 0; i < len(got); i++ {
+			if got[i] != ',' {
+				stripped = append(stripped, got[i])
+			}
+		}
+		if string(stripped) != digits {
+			t.Errorf("Format(%d) with GroupSize=%d = %q; stripping separators gives %q, want %q", v, groupSize, got, stripped, digits)
+		}
+	})
+}